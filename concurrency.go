@@ -0,0 +1,28 @@
+package main
+
+import "sync"
+
+// runConcurrent calls fn once per item, running up to workers calls at a time, and returns
+// their results in the same order as items regardless of which goroutine finishes first.
+func runConcurrent[T, R any](items []T, workers int, fn func(T) R) []R {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]R, len(items))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = fn(item)
+		}(i, item)
+	}
+
+	wg.Wait()
+	return results
+}
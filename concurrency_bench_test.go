@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// benchTreeSize is the number of synthetic files generated for the concurrency benchmarks.
+const benchTreeSize = 1000
+
+// newBenchTree writes benchTreeSize trivial Go files, each in its own directory (so package
+// mode has as many packages to dispatch as file mode has files), and returns their paths.
+func newBenchTree(b *testing.B) []string {
+	b.Helper()
+	root := b.TempDir()
+
+	files := make([]string, benchTreeSize)
+	for i := 0; i < benchTreeSize; i++ {
+		dir := filepath.Join(root, fmt.Sprintf("pkg%d", i))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			b.Fatalf("failed to create %s: %v", dir, err)
+		}
+		path := filepath.Join(dir, "file.go")
+		src := fmt.Sprintf("package pkg%d\n\n// Exported is a sample exported function.\nfunc Exported() int { return %d }\n", i, i)
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			b.Fatalf("failed to write %s: %v", path, err)
+		}
+		files[i] = path
+	}
+	return files
+}
+
+// BenchmarkSummarizeFilesSerial summarizes benchTreeSize files one at a time (workers=1),
+// as a baseline for BenchmarkSummarizeFilesConcurrent.
+func BenchmarkSummarizeFilesSerial(b *testing.B) {
+	files := newBenchTree(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		summarizeFilesConcurrently(io.Discard, files, 1)
+	}
+}
+
+// BenchmarkSummarizeFilesConcurrent summarizes the same tree across NumCPU workers, showing
+// the wall-clock improvement from dispatching files across a worker pool.
+func BenchmarkSummarizeFilesConcurrent(b *testing.B) {
+	files := newBenchTree(b)
+	workers := runtime.NumCPU()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		summarizeFilesConcurrently(io.Discard, files, workers)
+	}
+}
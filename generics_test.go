@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGenericsRendering checks that generic functions, methods on generic receivers, and
+// constraint interfaces are rendered with their type parameters and constraints intact.
+func TestGenericsRendering(t *testing.T) {
+	s, err := summarizePackage("testdata", nil)
+	if err != nil {
+		t.Fatalf("summarizePackage failed: %v", err)
+	}
+
+	var mapFunc *FuncInfo
+	for i := range s.Funcs {
+		if s.Funcs[i].Name == "Map" {
+			mapFunc = &s.Funcs[i]
+		}
+	}
+	if mapFunc == nil {
+		t.Fatal("expected to find generic function Map")
+	}
+	if mapFunc.Signature != "func Map[T any, U comparable](in []T, f func(T) U) []U" {
+		t.Errorf("unexpected signature for Map: %q", mapFunc.Signature)
+	}
+
+	var setType *TypeInfo
+	for i := range s.Types {
+		if s.Types[i].Name == "Set" {
+			setType = &s.Types[i]
+		}
+	}
+	if setType == nil {
+		t.Fatal("expected to find generic type Set")
+	}
+	if !strings.HasPrefix(setType.Decl, "type Set[T comparable] struct {") {
+		t.Errorf("expected Set's decl to carry its type params, got %q", setType.Decl)
+	}
+
+	var addMethod *FuncInfo
+	for i := range setType.Methods {
+		if setType.Methods[i].Name == "Add" {
+			addMethod = &setType.Methods[i]
+		}
+	}
+	if addMethod == nil {
+		t.Fatal("expected Set to have a nested Add method")
+	}
+	if addMethod.Signature != "func (s *Set[T]) Add(v T)" {
+		t.Errorf("unexpected signature for a method on a generic receiver: %q", addMethod.Signature)
+	}
+
+	var numberType *TypeInfo
+	for i := range s.Types {
+		if s.Types[i].Name == "Number" {
+			numberType = &s.Types[i]
+		}
+	}
+	if numberType == nil {
+		t.Fatal("expected to find constraint interface Number")
+	}
+	if !strings.Contains(numberType.Decl, "~int | ~int32 | ~int64 | ~float32 | ~float64") {
+		t.Errorf("expected Number's decl to include its union constraint, got %q", numberType.Decl)
+	}
+}
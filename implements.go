@@ -0,0 +1,160 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// externalInterface is an exported interface gathered from some other package in the same
+// run, tagged with the directory it came from so computeImplements can exclude the package
+// currently under analysis (its interfaces are already covered, by bare name, via localInterfaces).
+// The directory, not the import path, is what uniquely identifies a package here: build.ImportDir
+// reports "." for every directory outside GOPATH/a resolvable module, so sibling packages in a
+// scanned tree commonly share the same ImportPath.
+type externalInterface struct {
+	dir   string
+	iface *types.Interface
+}
+
+// computeImplements type-checks files (the parsed source of a single package, rooted at dir)
+// and returns, for every exported named type, the sorted list of exported interfaces it
+// satisfies: the package's own exported interfaces, external (interfaces gathered from sibling
+// packages in the same run by moduleInterfaces, keyed "pkgname.IfaceName" — nil if none are
+// available), and a small set of well-known stdlib interfaces. Type checking is best-effort —
+// if it fails (e.g. the package doesn't compile on its own), the caller should treat a non-nil
+// error as "no implements info available" rather than fatal.
+func computeImplements(fset *token.FileSet, files []*ast.File, importPath, dir string, external map[string]externalInterface) (map[string][]string, error) {
+	imp := importer.ForCompiler(fset, "source", nil)
+	conf := types.Config{Importer: imp, Error: func(error) {}}
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+
+	pkg, err := conf.Check(importPath, fset, files, info)
+	if err != nil && pkg == nil {
+		return nil, err
+	}
+
+	candidates := localInterfaces(pkg)
+	for name, ei := range external {
+		if ei.dir == dir {
+			continue // already covered, by bare name, via localInterfaces above
+		}
+		candidates[name] = ei.iface
+	}
+	for name, iface := range wellKnownInterfaces(imp) {
+		candidates[name] = iface
+	}
+
+	result := make(map[string][]string)
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !obj.Exported() {
+			continue
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		if _, isInterface := named.Underlying().(*types.Interface); isInterface {
+			continue
+		}
+
+		var matches []string
+		for ifaceName, iface := range candidates {
+			if iface.NumMethods() == 0 {
+				continue // skip the empty interface; "implements interface{}" is not useful
+			}
+			if types.Implements(named, iface) || types.Implements(types.NewPointer(named), iface) {
+				matches = append(matches, ifaceName)
+			}
+		}
+		if len(matches) > 0 {
+			sort.Strings(matches)
+			result[name] = matches
+		}
+	}
+	return result, nil
+}
+
+// moduleInterfaces type-checks every package in dirs and collects their exported interfaces,
+// keyed "pkgname.IfaceName" to match the style of wellKnownInterfaces and avoid colliding with
+// a package's own local interfaces (which computeImplements keys by bare name). Each entry is
+// tagged with the directory it came from, so computeImplements can skip the package it is
+// currently analyzing and avoid reporting that package's own interfaces twice. The result is
+// meant to be passed as computeImplements' external parameter so a type's "implements" list can
+// include interfaces declared in sibling packages, not just its own. A package that fails to
+// resolve, parse, or type-check is skipped rather than failing the whole pass.
+func moduleInterfaces(dirs []string) map[string]externalInterface {
+	all := make(map[string]externalInterface)
+	for _, dir := range dirs {
+		fset, files, buildPkg, err := parsePackageFiles(dir)
+		if err != nil || len(files) == 0 {
+			continue
+		}
+
+		imp := importer.ForCompiler(fset, "source", nil)
+		conf := types.Config{Importer: imp, Error: func(error) {}}
+		pkg, err := conf.Check(buildPkg.ImportPath, fset, files, nil)
+		if err != nil && pkg == nil {
+			continue
+		}
+
+		for name, iface := range localInterfaces(pkg) {
+			all[pkg.Name()+"."+name] = externalInterface{dir: dir, iface: iface}
+		}
+	}
+	return all
+}
+
+// localInterfaces returns the exported interface types declared in pkg, keyed by name.
+func localInterfaces(pkg *types.Package) map[string]*types.Interface {
+	ifaces := make(map[string]*types.Interface)
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !obj.Exported() {
+			continue
+		}
+		if iface, ok := obj.Type().Underlying().(*types.Interface); ok {
+			ifaces[name] = iface
+		}
+	}
+	return ifaces
+}
+
+// wellKnownInterfaces resolves a small, fixed set of commonly-implemented stdlib interfaces
+// via imp, so types can be checked against them even though they live outside the package
+// being summarized.
+func wellKnownInterfaces(imp types.Importer) map[string]*types.Interface {
+	ifaces := make(map[string]*types.Interface)
+
+	lookup := func(qualifiedName, path, name string) {
+		pkg, err := imp.Import(path)
+		if err != nil {
+			return
+		}
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			return
+		}
+		if iface, ok := obj.Type().Underlying().(*types.Interface); ok {
+			ifaces[qualifiedName] = iface
+		}
+	}
+
+	lookup("io.Reader", "io", "Reader")
+	lookup("io.Writer", "io", "Writer")
+	lookup("io.Closer", "io", "Closer")
+	lookup("fmt.Stringer", "fmt", "Stringer")
+
+	if errType := types.Universe.Lookup("error"); errType != nil {
+		if iface, ok := errType.Type().Underlying().(*types.Interface); ok {
+			ifaces["error"] = iface
+		}
+	}
+
+	return ifaces
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestImplementsLocalInterface checks that a concrete type satisfying a package-local
+// exported interface is annotated with that interface's name.
+func TestImplementsLocalInterface(t *testing.T) {
+	s, err := summarizePackage("testdata", nil)
+	if err != nil {
+		t.Fatalf("summarizePackage failed: %v", err)
+	}
+
+	var got *TypeInfo
+	for i := range s.Types {
+		if s.Types[i].Name == "StructWithInterface" {
+			got = &s.Types[i]
+		}
+	}
+	if got == nil {
+		t.Fatal("expected to find StructWithInterface")
+	}
+	if !reflect.DeepEqual(got.Implements, []string{"PublicInterface"}) {
+		t.Errorf("expected StructWithInterface to implement [PublicInterface], got %v", got.Implements)
+	}
+}
+
+// TestModuleInterfaces checks that a type is annotated with an interface declared in a sibling
+// package discovered in the same run, not just its own package.
+func TestModuleInterfaces(t *testing.T) {
+	summaries := summarizePackagesConcurrently([]string{"testdata/crosspkg/iface", "testdata/crosspkg/impl"}, 2)
+
+	var got *TypeInfo
+	for i := range summaries {
+		if summaries[i].Package != "impl" {
+			continue
+		}
+		for j := range summaries[i].Types {
+			if summaries[i].Types[j].Name == "English" {
+				got = &summaries[i].Types[j]
+			}
+		}
+	}
+	if got == nil {
+		t.Fatal("expected to find English in package impl")
+	}
+	if !reflect.DeepEqual(got.Implements, []string{"iface.Greeter"}) {
+		t.Errorf("expected English to implement [iface.Greeter], got %v", got.Implements)
+	}
+}
+
+// TestModuleInterfacesExcludesOwnPackage checks that a type satisfying an interface declared in
+// its own package is reported only once (by its bare name), even though summarizePackagesConcurrently
+// also gathers that package's interfaces into the external/module-wide candidate set.
+func TestModuleInterfacesExcludesOwnPackage(t *testing.T) {
+	summaries := summarizePackagesConcurrently([]string{"testdata", "testdata/crosspkg/iface", "testdata/crosspkg/impl"}, 2)
+
+	var got *TypeInfo
+	for i := range summaries {
+		if summaries[i].Package != "testdata" {
+			continue
+		}
+		for j := range summaries[i].Types {
+			if summaries[i].Types[j].Name == "StructWithInterface" {
+				got = &summaries[i].Types[j]
+			}
+		}
+	}
+	if got == nil {
+		t.Fatal("expected to find StructWithInterface in package testdata")
+	}
+	if !reflect.DeepEqual(got.Implements, []string{"PublicInterface"}) {
+		t.Errorf("expected StructWithInterface to implement [PublicInterface] exactly once, got %v", got.Implements)
+	}
+}
@@ -1,3 +1,11 @@
+// Command gosummarize prints a condensed, LLM- and human-readable summary of a Go codebase's
+// exported API: packages, consts, vars, funcs, and types with their doc comments.
+//
+// In the default (package) mode, each type's summary includes an "implements" list of the
+// exported interfaces it satisfies. That list spans every package discovered under the given
+// root directory in this invocation, not the full Go module: if the root only covers part of
+// a module (e.g. you point gosummarize at a subdirectory), interfaces declared outside that
+// subtree won't be seen. -file mode does no type-checking and never reports "implements".
 package main
 
 import (
@@ -5,36 +13,149 @@ import (
 	"flag"
 	"fmt"
 	"go/ast"
+	"go/build"
+	"go/doc"
 	"go/parser"
 	"go/printer"
 	"go/token"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
 	"strings"
 )
 
-// findGoFiles returns a slice of all Go files in the given directory and its subdirectories
-// If ignoreTests is true, files ending with _test.go will be ignored
-func findGoFiles(root string, ignoreTests bool) ([]string, error) {
-	var files []string
-	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+// deprecatedRE matches the godoc convention for marking a deprecation notice
+// inside a doc comment, e.g. "Deprecated: use NewThing instead."
+var deprecatedRE = regexp.MustCompile(`(?m)^ *Deprecated: *`)
+
+// packageDirs returns the sorted, de-duplicated set of directories containing the given files.
+func packageDirs(files []string) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, f := range files {
+		dir := filepath.Dir(f)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// deprecatedNotice extracts the "Deprecated: ..." paragraph from a doc comment, if present.
+func deprecatedNotice(text string) string {
+	loc := deprecatedRE.FindStringIndex(text)
+	if loc == nil {
+		return ""
+	}
+	rest := text[loc[1]:]
+	if idx := strings.Index(rest, "\n\n"); idx != -1 {
+		rest = rest[:idx]
+	}
+	return strings.TrimSpace(strings.ReplaceAll(rest, "\n", " "))
+}
+
+// printNodeDoc writes a doc comment and any deprecation notice it contains to w, indented
+// to match the rest of the summary output.
+func printNodeDoc(w io.Writer, doc string) {
+	if doc != "" {
+		fmt.Fprintf(w, "    %s\n", strings.TrimSpace(doc))
+	}
+	if dep := deprecatedNotice(doc); dep != "" {
+		fmt.Fprintf(w, "    // Deprecated: %s\n", dep)
+	}
+}
+
+// renderNode renders an AST node back to Go source text using go/printer.
+func renderNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, node)
+	return buf.String()
+}
+
+// funcSignature renders a function/method declaration in the same format Go source uses,
+// e.g. "func (s *Type) Method(arg string) error".
+func funcSignature(fset *token.FileSet, fn *ast.FuncDecl) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, fn.Type)
+	signature := strings.TrimPrefix(buf.String(), "func")
+
+	if fn.Recv != nil {
+		recvList := fn.Recv.List[0]
+
+		var recvBuf bytes.Buffer
+		printer.Fprint(&recvBuf, fset, recvList.Type)
+		recvType := recvBuf.String()
+
+		recvVarName := ""
+		if len(recvList.Names) > 0 {
+			recvVarName = recvList.Names[0].Name
 		}
-		if !info.IsDir() && strings.HasSuffix(path, ".go") {
-			// Skip test files if ignoreTests flag is set
-			if ignoreTests && strings.HasSuffix(path, "_test.go") {
-				return nil
+
+		if recvVarName != "" {
+			return fmt.Sprintf("func (%s %s) %s%s", recvVarName, recvType, fn.Name.Name, signature)
+		}
+		return fmt.Sprintf("func (%s) %s%s", recvType, fn.Name.Name, signature)
+	}
+
+	return fmt.Sprintf("func %s%s", fn.Name.Name, signature)
+}
+
+// formatTypeSpec renders a type declaration, filtering struct fields down to exported ones
+// and including the type parameter list (e.g. "[T comparable]") for generic types.
+func formatTypeSpec(fset *token.FileSet, s *ast.TypeSpec) string {
+	var out strings.Builder
+	name := s.Name.Name + typeParamsSuffix(fset, s.TypeParams)
+
+	if structType, ok := s.Type.(*ast.StructType); ok && structType.Fields != nil {
+		fmt.Fprintf(&out, "type %s struct {\n", name)
+		for _, field := range structType.Fields.List {
+			if len(field.Names) > 0 && field.Names[0].IsExported() {
+				var fieldBuf bytes.Buffer
+				printer.Fprint(&fieldBuf, fset, field.Type)
+				fieldType := fieldBuf.String()
+
+				if field.Doc != nil && field.Doc.Text() != "" {
+					fmt.Fprintf(&out, "\t// %s\n", strings.TrimSpace(field.Doc.Text()))
+				}
+				fmt.Fprintf(&out, "\t%s\t%s\n", field.Names[0].Name, fieldType)
 			}
-			files = append(files, path)
 		}
-		return nil
-	})
-	return files, err
+		out.WriteString("}")
+		return out.String()
+	}
+
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, s.Type)
+	fmt.Fprintf(&out, "type %s %s", name, buf.String())
+	return out.String()
 }
 
-// summarizeFile parses a Go file and extracts information about exported declarations
-func summarizeFile(filePath string) error {
+// typeParamsSuffix renders a type parameter list (e.g. "[T comparable, U any]") for a generic
+// type declaration, or "" if params is nil (the type isn't generic). go/printer doesn't support
+// printing a bare *ast.FieldList on its own, so each field is rendered manually.
+func typeParamsSuffix(fset *token.FileSet, params *ast.FieldList) string {
+	if params == nil || len(params.List) == 0 {
+		return ""
+	}
+	var groups []string
+	for _, field := range params.List {
+		var names []string
+		for _, n := range field.Names {
+			names = append(names, n.Name)
+		}
+		groups = append(groups, strings.Join(names, ", ")+" "+renderNode(fset, field.Type))
+	}
+	return "[" + strings.Join(groups, ", ") + "]"
+}
+
+// summarizeFile parses a single Go file and writes information about its exported
+// declarations to w. This is the legacy per-file mode, selectable with -file.
+func summarizeFile(w io.Writer, filePath string) error {
 	fset := token.NewFileSet()
 
 	// Parse the file
@@ -44,20 +165,20 @@ func summarizeFile(filePath string) error {
 	}
 
 	// Print clear file start marker with filename
-	fmt.Printf("<<<FILE_START>>> %s\n\n", filePath)
+	fmt.Fprintf(w, "<<<FILE_START>>> %s\n\n", filePath)
 
 	// Extract exported declarations
 	for _, decl := range file.Decls {
-		processDeclaration(decl, file, fset)
+		processDeclaration(w, decl, file, fset)
 	}
 
 	// Print clear file end marker
-	fmt.Printf("<<<FILE_END>>> %s\n\n", filePath)
+	fmt.Fprintf(w, "<<<FILE_END>>> %s\n\n", filePath)
 	return nil
 }
 
-// processDeclaration extracts and prints information about exported declarations
-func processDeclaration(decl ast.Decl, file *ast.File, fset *token.FileSet) {
+// processDeclaration writes information about a declaration's exported identifiers to w.
+func processDeclaration(w io.Writer, decl ast.Decl, file *ast.File, fset *token.FileSet) {
 	// Handle functions and methods
 	if fn, ok := decl.(*ast.FuncDecl); ok {
 		if fn.Name.IsExported() {
@@ -66,47 +187,9 @@ func processDeclaration(decl ast.Decl, file *ast.File, fset *token.FileSet) {
 				doc = fn.Doc.Text()
 			}
 
-			// We no longer need to distinguish between method/func in the output
-			// as we're using the standard Go syntax format for both
-
-			// Get the full function signature
-			var buf bytes.Buffer
-			printer.Fprint(&buf, fset, fn.Type)
-			signature := buf.String()
-
-			// Remove "func" from the signature as we'll add it manually
-			signature = strings.TrimPrefix(signature, "func")
-
-			// For methods, add the receiver and display in a format similar to source code
-			if fn.Recv != nil {
-				// Let's manually extract the receiver details
-				recvList := fn.Recv.List[0] // Get the first (and only) receiver parameter
-
-				// Get the type of the receiver
-				var typeNameBuf bytes.Buffer
-				printer.Fprint(&typeNameBuf, fset, recvList.Type)
-				recvType := typeNameBuf.String()
-
-				// Get the variable name of the receiver (if any)
-				recvVarName := ""
-				if len(recvList.Names) > 0 {
-					recvVarName = recvList.Names[0].Name
-				}
-
-				// Format like Go source: func (s *Type) Method()
-				if recvVarName != "" {
-					fmt.Printf("func (%s %s) %s%s\n", recvVarName, recvType, fn.Name.Name, signature)
-				} else {
-					fmt.Printf("func (%s) %s%s\n", recvType, fn.Name.Name, signature)
-				}
-			} else {
-				fmt.Printf("func %s%s\n", fn.Name.Name, signature)
-			}
-
-			if doc != "" {
-				fmt.Printf("    %s\n", strings.TrimSpace(doc))
-			}
-			fmt.Println()
+			fmt.Fprintln(w, funcSignature(fset, fn))
+			printNodeDoc(w, doc)
+			fmt.Fprintln(w)
 		}
 		return
 	}
@@ -122,40 +205,9 @@ func processDeclaration(decl ast.Decl, file *ast.File, fset *token.FileSet) {
 						doc = gen.Doc.Text()
 					}
 
-					// For structs, we need to filter out unexported fields
-					if structType, ok := s.Type.(*ast.StructType); ok && structType.Fields != nil {
-						fmt.Printf("type %s struct {\n", s.Name.Name)
-
-						// Print only exported fields
-						for _, field := range structType.Fields.List {
-							if len(field.Names) > 0 && field.Names[0].IsExported() {
-								var fieldBuf bytes.Buffer
-								printer.Fprint(&fieldBuf, fset, field.Type)
-								fieldType := fieldBuf.String()
-
-								// Print field doc if exists
-								if field.Doc != nil && field.Doc.Text() != "" {
-									fieldDoc := strings.TrimSpace(field.Doc.Text())
-									fmt.Printf("\t// %s\n", fieldDoc)
-								}
-
-								fmt.Printf("\t%s\t%s\n", field.Names[0].Name, fieldType)
-							}
-						}
-						fmt.Printf("}\n")
-					} else {
-						// For non-struct types, print the full definition
-						var buf bytes.Buffer
-						printer.Fprint(&buf, fset, s.Type)
-						typeDefinition := buf.String()
-
-						fmt.Printf("type %s %s\n", s.Name.Name, typeDefinition)
-					}
-
-					if doc != "" {
-						fmt.Printf("    %s\n", strings.TrimSpace(doc))
-					}
-					fmt.Println()
+					fmt.Fprintln(w, formatTypeSpec(fset, s))
+					printNodeDoc(w, doc)
+					fmt.Fprintln(w)
 				}
 
 			case *ast.ValueSpec:
@@ -189,11 +241,9 @@ func processDeclaration(decl ast.Decl, file *ast.File, fset *token.FileSet) {
 							valueStr = " = " + buf.String()
 						}
 
-						fmt.Printf("%s %s%s%s\n", declType, name.Name, typeStr, valueStr)
-						if doc != "" {
-							fmt.Printf("    %s\n", strings.TrimSpace(doc))
-						}
-						fmt.Println()
+						fmt.Fprintf(w, "%s %s%s%s\n", declType, name.Name, typeStr, valueStr)
+						printNodeDoc(w, doc)
+						fmt.Fprintln(w)
 					}
 				}
 			}
@@ -201,23 +251,181 @@ func processDeclaration(decl ast.Decl, file *ast.File, fset *token.FileSet) {
 	}
 }
 
+// parsePackageFiles resolves the package in dir with go/build and parses its Go files. A nil
+// buildPkg with a nil error means the directory has no Go package.
+func parsePackageFiles(dir string) (*token.FileSet, []*ast.File, *build.Package, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		if _, ok := err.(*build.NoGoError); ok {
+			return nil, nil, nil, nil
+		}
+		return nil, nil, nil, fmt.Errorf("error resolving package in %s: %v", dir, err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range buildPkg.GoFiles {
+		path := filepath.Join(dir, name)
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+		files = append(files, f)
+	}
+	return fset, files, buildPkg, nil
+}
+
+// summarizePackage resolves and parses the package in dir and builds a Summary from its files
+// with go/doc. external holds exported interfaces gathered from sibling packages elsewhere in
+// the directory tree being summarized (see moduleInterfaces); pass nil if none are available.
+// The returned Summary is rendered by the caller in whichever format was requested. A nil
+// Summary with a nil error means the directory has no Go package.
+func summarizePackage(dir string, external map[string]externalInterface) (*Summary, error) {
+	fset, files, buildPkg, err := parsePackageFiles(dir)
+	if err != nil || buildPkg == nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	docPkg, err := doc.NewFromFiles(fset, files, buildPkg.ImportPath)
+	if err != nil {
+		return nil, fmt.Errorf("error building doc for %s: %v", dir, err)
+	}
+
+	// Type-checking is best-effort: a package that doesn't compile standalone (e.g. it
+	// depends on files excluded by build constraints) simply gets no "implements" info.
+	implements, _ := computeImplements(fset, files, buildPkg.ImportPath, dir, external)
+
+	summary := buildSummary(fset, dir, docPkg, implements)
+	return &summary, nil
+}
+
+// goFilesIn runs findGoFiles against the real OS filesystem rooted at dirPath, returning
+// OS paths (joined with dirPath) rather than the fs.FS-relative paths findGoFiles itself deals in.
+func goFilesIn(dirPath string, opts Options) ([]string, error) {
+	relFiles, err := findGoFiles(os.DirFS(dirPath), ".", opts)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, len(relFiles))
+	for i, f := range relFiles {
+		files[i] = filepath.Join(dirPath, f)
+	}
+	return files, nil
+}
+
+// fileResult holds one worker's output from summarizing a single file, buffered so it can be
+// flushed to stdout in the original file order once every worker has finished.
+type fileResult struct {
+	buf bytes.Buffer
+	err error
+}
+
+// summarizeFilesConcurrently runs summarizeFile across up to workers goroutines and writes
+// their buffered output to w in the original file order.
+func summarizeFilesConcurrently(w io.Writer, files []string, workers int) {
+	results := runConcurrent(files, workers, func(file string) fileResult {
+		var r fileResult
+		r.err = summarizeFile(&r.buf, file)
+		return r
+	})
+
+	for _, r := range results {
+		if r.err != nil {
+			fmt.Fprintln(os.Stderr, r.err)
+			continue
+		}
+		w.Write(r.buf.Bytes())
+	}
+}
+
+// packageResult holds one worker's output from summarizing a single package.
+type packageResult struct {
+	summary *Summary
+	err     error
+}
+
+// summarizePackagesConcurrently runs summarizePackage across up to workers goroutines and
+// returns the resulting Summaries in the original directory order. Before dispatching, it
+// gathers the exported interfaces of every package in dirs so each package's "implements"
+// list can include interfaces declared in its siblings, not just its own package.
+func summarizePackagesConcurrently(dirs []string, workers int) []Summary {
+	external := moduleInterfaces(dirs)
+
+	results := runConcurrent(dirs, workers, func(dir string) packageResult {
+		s, err := summarizePackage(dir, external)
+		return packageResult{summary: s, err: err}
+	})
+
+	var summaries []Summary
+	for _, r := range results {
+		if r.err != nil {
+			fmt.Fprintln(os.Stderr, r.err)
+			continue
+		}
+		if r.summary != nil {
+			summaries = append(summaries, *r.summary)
+		}
+	}
+	return summaries
+}
+
 func main() {
 	// Define command-line flags
 	ignoreTests := flag.Bool("t", false, "Ignore test files (files ending with _test.go)")
+	fileMode := flag.Bool("file", false, "Summarize file-by-file instead of grouping by package (legacy mode)")
+	format := flag.String("format", "text", "Output format: text, json, or markdown")
+	includeVendor := flag.Bool("vendor", false, "Include vendor/ directories")
+	includeTestdata := flag.Bool("testdata", false, "Include testdata/ directories")
+	jobs := flag.Int("j", runtime.NumCPU(), "Number of files or packages to summarize concurrently")
 	flag.Parse()
 
 	// Get the directory path from command line arguments
 	args := flag.Args()
 	if len(args) < 1 {
-		fmt.Println("Usage: gosummarize [-t] <directory>")
+		fmt.Println("Usage: gosummarize [-t] [-file] [-format text|json|markdown] [-vendor] [-testdata] [-j N] <directory>")
 		flag.PrintDefaults()
 		os.Exit(1)
 	}
 
 	dirPath := args[0]
+	opts := Options{
+		IgnoreTests:     *ignoreTests,
+		IncludeVendor:   *includeVendor,
+		IncludeTestdata: *includeTestdata,
+	}
+
+	if *fileMode {
+		if *format != "text" {
+			fmt.Printf("-format %s is not supported with -file\n", *format)
+			os.Exit(1)
+		}
+
+		files, err := goFilesIn(dirPath, opts)
+		if err != nil {
+			fmt.Printf("Error finding Go files: %v\n", err)
+			os.Exit(1)
+		}
 
-	// Find all Go files in the directory and subdirectories
-	files, err := findGoFiles(dirPath, *ignoreTests)
+		if len(files) == 0 {
+			fmt.Println("No Go files found in the specified directory or its subdirectories")
+			os.Exit(0)
+		}
+
+		summarizeFilesConcurrently(os.Stdout, files, *jobs)
+		return
+	}
+
+	renderer, err := rendererFor(*format)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	// Default mode: group files by package (directory) and summarize each package.
+	files, err := goFilesIn(dirPath, opts)
 	if err != nil {
 		fmt.Printf("Error finding Go files: %v\n", err)
 		os.Exit(1)
@@ -228,11 +436,10 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Process each file
-	for _, file := range files {
-		err := summarizeFile(file)
-		if err != nil {
-			fmt.Println(err)
-		}
+	summaries := summarizePackagesConcurrently(packageDirs(files), *jobs)
+
+	if err := renderer(os.Stdout, summaries); err != nil {
+		fmt.Printf("Error rendering summary: %v\n", err)
+		os.Exit(1)
 	}
 }
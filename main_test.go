@@ -49,7 +49,7 @@ func TestFindGoFiles(t *testing.T) {
 	}
 
 	// Test findGoFiles function without ignoring test files
-	foundFiles, err := findGoFiles(tmpDir, false)
+	foundFiles, err := findGoFiles(os.DirFS(tmpDir), ".", Options{})
 	if err != nil {
 		t.Fatalf("findGoFiles failed: %v", err)
 	}
@@ -68,7 +68,7 @@ func TestFindGoFiles(t *testing.T) {
 	}
 
 	// Test findGoFiles function while ignoring test files
-	foundFiles, err = findGoFiles(tmpDir, true)
+	foundFiles, err = findGoFiles(os.DirFS(tmpDir), ".", Options{IgnoreTests: true})
 	if err != nil {
 		t.Fatalf("findGoFiles with ignoreTests=true failed: %v", err)
 	}
@@ -113,7 +113,7 @@ func TestEmptyDirectoryWithSubdirs(t *testing.T) {
 
 	// Test finding files from the empty top directory
 	emptyDir := filepath.Join(tmpDir, "empty")
-	foundFiles, err := findGoFiles(emptyDir, false)
+	foundFiles, err := findGoFiles(os.DirFS(emptyDir), ".", Options{})
 	if err != nil {
 		t.Fatalf("findGoFiles failed on empty directory: %v", err)
 	}
@@ -123,34 +123,21 @@ func TestEmptyDirectoryWithSubdirs(t *testing.T) {
 		t.Errorf("Expected to find 1 Go file in subdirectories, but found %d", len(foundFiles))
 	}
 
-	// Verify the file found is actually our nested Go file
-	if len(foundFiles) > 0 && foundFiles[0] != nestedGoFile {
-		t.Errorf("Expected to find %s, but found %s", nestedGoFile, foundFiles[0])
+	// Verify the file found is actually our nested Go file, relative to emptyDir
+	wantRelPath := filepath.Join("nested", "nested.go")
+	if len(foundFiles) > 0 && foundFiles[0] != wantRelPath {
+		t.Errorf("Expected to find %s, but found %s", wantRelPath, foundFiles[0])
 	}
 }
 
 // TestSummarizeFile tests the summarizeFile function
 func TestSummarizeFile(t *testing.T) {
-	// Capture stdout to check output
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
 	// Call summarizeFile on our sample file
+	var buf bytes.Buffer
 	testFile := "./testdata/sample.go"
-	err := summarizeFile(testFile)
-	if err != nil {
+	if err := summarizeFile(&buf, testFile); err != nil {
 		t.Fatalf("summarizeFile failed: %v", err)
 	}
-
-	// Restore stdout and get the output
-	w.Close()
-	os.Stdout = oldStdout
-	var buf bytes.Buffer
-	_, err = buf.ReadFrom(r)
-	if err != nil {
-		t.Fatalf("Failed to read output: %v", err)
-	}
 	output := buf.String()
 
 	// Verify the output contains expected elements
@@ -187,6 +174,60 @@ func TestSummarizeFile(t *testing.T) {
 	}
 }
 
+// TestSummarizeFilesConcurrentlyKeepsErrorsOffTheWriter verifies that a failing file doesn't
+// interleave error text into w, which would corrupt non-text output formats.
+func TestSummarizeFilesConcurrentlyKeepsErrorsOffTheWriter(t *testing.T) {
+	restoreStderr := captureStderr(t)
+
+	var buf bytes.Buffer
+	summarizeFilesConcurrently(&buf, []string{"./testdata/sample.go", "./testdata/does-not-exist.go"}, 2)
+
+	if strings.Contains(buf.String(), "does-not-exist") {
+		t.Errorf("expected error for missing file to stay off the writer, got: %s", buf.String())
+	}
+
+	if stderr := restoreStderr(); !strings.Contains(stderr, "does-not-exist") {
+		t.Errorf("expected missing file error on stderr, got: %s", stderr)
+	}
+}
+
+// TestSummarizePackagesConcurrentlyKeepsErrorsOffStdout verifies that a package which fails to
+// summarize reports its error on stderr instead of stdout, so stdout stays valid for formats
+// like JSON that a failing package would otherwise corrupt.
+func TestSummarizePackagesConcurrentlyKeepsErrorsOffStdout(t *testing.T) {
+	restoreStderr := captureStderr(t)
+
+	summaries := summarizePackagesConcurrently([]string{"testdata", "testdata/does-not-exist"}, 2)
+
+	if len(summaries) != 1 {
+		t.Fatalf("expected 1 summary from the valid package, got %d", len(summaries))
+	}
+
+	if stderr := restoreStderr(); !strings.Contains(stderr, "does-not-exist") {
+		t.Errorf("expected missing package error on stderr, got: %s", stderr)
+	}
+}
+
+// captureStderr redirects os.Stderr to an in-memory pipe for the duration of the test and
+// returns a function that restores it and yields everything written in the meantime.
+func captureStderr(t *testing.T) func() string {
+	t.Helper()
+	original := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	os.Stderr = w
+
+	return func() string {
+		os.Stderr = original
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		return buf.String()
+	}
+}
+
 // TestMain runs the tests
 func TestMain(m *testing.M) {
 	// Setup code if needed
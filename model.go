@@ -0,0 +1,169 @@
+package main
+
+import (
+	"go/ast"
+	"go/doc"
+	"go/token"
+)
+
+// Summary is the neutral, renderer-agnostic result of summarizing a single package.
+// It is built once from a *doc.Package and then handed to a text/JSON/Markdown renderer.
+type Summary struct {
+	Package    string      `json:"package"`
+	Dir        string      `json:"dir"`
+	Doc        string      `json:"doc,omitempty"`
+	Deprecated string      `json:"deprecated,omitempty"`
+	Consts     []ValueInfo `json:"consts,omitempty"`
+	Vars       []ValueInfo `json:"vars,omitempty"`
+	Funcs      []FuncInfo  `json:"funcs,omitempty"`
+	Types      []TypeInfo  `json:"types,omitempty"`
+}
+
+// ValueInfo describes a single exported const or var identifier.
+type ValueInfo struct {
+	Name       string `json:"name"`
+	Decl       string `json:"decl"`
+	Doc        string `json:"doc,omitempty"`
+	Deprecated string `json:"deprecated,omitempty"`
+}
+
+// FuncInfo describes a single exported function or method.
+type FuncInfo struct {
+	Name       string `json:"name"`
+	Signature  string `json:"signature"`
+	Receiver   string `json:"receiver,omitempty"`
+	Doc        string `json:"doc,omitempty"`
+	Pos        string `json:"pos"`
+	Deprecated string `json:"deprecated,omitempty"`
+}
+
+// TypeInfo describes a single exported type along with the consts, vars, and methods go/doc
+// associates with it.
+type TypeInfo struct {
+	Name       string      `json:"name"`
+	Decl       string      `json:"decl"`
+	Doc        string      `json:"doc,omitempty"`
+	Deprecated string      `json:"deprecated,omitempty"`
+	Implements []string    `json:"implements,omitempty"`
+	Consts     []ValueInfo `json:"consts,omitempty"`
+	Vars       []ValueInfo `json:"vars,omitempty"`
+	Methods    []FuncInfo  `json:"methods,omitempty"`
+}
+
+// buildSummary translates a *doc.Package into the neutral Summary model. implements maps a
+// type name to the sorted names of the interfaces it satisfies, as computed by
+// computeImplements; it may be nil if type-checking the package failed.
+func buildSummary(fset *token.FileSet, dir string, docPkg *doc.Package, implements map[string][]string) Summary {
+	s := Summary{
+		Package:    docPkg.Name,
+		Dir:        dir,
+		Doc:        docPkg.Doc,
+		Deprecated: deprecatedNotice(docPkg.Doc),
+	}
+
+	for _, c := range docPkg.Consts {
+		s.Consts = append(s.Consts, valueInfos(fset, "const", c)...)
+	}
+	for _, v := range docPkg.Vars {
+		s.Vars = append(s.Vars, valueInfos(fset, "var", v)...)
+	}
+	for _, fn := range docPkg.Funcs {
+		s.Funcs = append(s.Funcs, funcInfo(fset, fn))
+	}
+	for _, t := range docPkg.Types {
+		ti := typeInfo(fset, t)
+		ti.Implements = implements[t.Name]
+		s.Types = append(s.Types, ti)
+	}
+
+	return s
+}
+
+// valueInfos expands a doc.Value (which may declare several names in one block, e.g.
+// a const group) into one ValueInfo per exported name.
+func valueInfos(fset *token.FileSet, keyword string, v *doc.Value) []ValueInfo {
+	var infos []ValueInfo
+	for _, spec := range v.Decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for _, name := range vs.Names {
+			if !name.IsExported() {
+				continue
+			}
+			infos = append(infos, ValueInfo{
+				Name:       name.Name,
+				Decl:       valueSpecDecl(fset, keyword, vs, name.Name),
+				Doc:        v.Doc,
+				Deprecated: deprecatedNotice(v.Doc),
+			})
+		}
+	}
+	return infos
+}
+
+// valueSpecDecl renders the "const X = 1" / "var X string" line for a single name in a
+// (possibly multi-name) ValueSpec.
+func valueSpecDecl(fset *token.FileSet, keyword string, vs *ast.ValueSpec, name string) string {
+	for i, n := range vs.Names {
+		if n.Name != name {
+			continue
+		}
+
+		typeStr := ""
+		if vs.Type != nil {
+			typeStr = " " + renderNode(fset, vs.Type)
+		}
+
+		valueStr := ""
+		if i < len(vs.Values) && vs.Values[i] != nil {
+			valueStr = " = " + renderNode(fset, vs.Values[i])
+		}
+
+		return keyword + " " + name + typeStr + valueStr
+	}
+	return keyword + " " + name
+}
+
+// funcInfo builds a FuncInfo from a doc.Func, which may describe a plain function or a method.
+func funcInfo(fset *token.FileSet, fn *doc.Func) FuncInfo {
+	return FuncInfo{
+		Name:       fn.Name,
+		Signature:  funcSignature(fset, fn.Decl),
+		Receiver:   fn.Recv,
+		Doc:        fn.Doc,
+		Pos:        fset.Position(fn.Decl.Pos()).String(),
+		Deprecated: deprecatedNotice(fn.Doc),
+	}
+}
+
+// typeInfo builds a TypeInfo from a doc.Type, nesting its associated consts, vars, and methods
+// the same way godoc groups a type with its method set.
+func typeInfo(fset *token.FileSet, t *doc.Type) TypeInfo {
+	info := TypeInfo{
+		Name:       t.Name,
+		Doc:        t.Doc,
+		Deprecated: deprecatedNotice(t.Doc),
+	}
+
+	for _, spec := range t.Decl.Specs {
+		ts, ok := spec.(*ast.TypeSpec)
+		if !ok || ts.Name.Name != t.Name {
+			continue
+		}
+		info.Decl = formatTypeSpec(fset, ts)
+	}
+
+	for _, c := range t.Consts {
+		info.Consts = append(info.Consts, valueInfos(fset, "const", c)...)
+	}
+	for _, v := range t.Vars {
+		info.Vars = append(info.Vars, valueInfos(fset, "var", v)...)
+	}
+	for _, m := range t.Methods {
+		info.Methods = append(info.Methods, funcInfo(fset, m))
+	}
+
+	return info
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// renderer writes a set of package Summaries to w in some output format.
+type renderer func(w io.Writer, summaries []Summary) error
+
+// rendererFor resolves the -format flag value to a renderer, or an error for unknown formats.
+func rendererFor(format string) (renderer, error) {
+	switch format {
+	case "text":
+		return renderText, nil
+	case "json":
+		return renderJSON, nil
+	case "markdown":
+		return renderMarkdown, nil
+	default:
+		return nil, fmt.Errorf("unknown -format %q (want text, json, or markdown)", format)
+	}
+}
+
+// renderText writes summaries in the original <<<FILE_START>>>/<<<FILE_END>>>-delimited
+// plain-text format.
+func renderText(w io.Writer, summaries []Summary) error {
+	for _, s := range summaries {
+		fmt.Fprintf(w, "<<<FILE_START>>> %s\n\n", s.Dir)
+
+		fmt.Fprintf(w, "package %s\n", s.Package)
+		renderTextDoc(w, s.Doc, s.Deprecated)
+		fmt.Fprintln(w)
+
+		for _, c := range s.Consts {
+			renderTextValue(w, c)
+		}
+		for _, v := range s.Vars {
+			renderTextValue(w, v)
+		}
+		for _, fn := range s.Funcs {
+			renderTextFunc(w, fn)
+		}
+		for _, t := range s.Types {
+			renderTextType(w, t)
+		}
+
+		fmt.Fprintf(w, "<<<FILE_END>>> %s\n\n", s.Dir)
+	}
+	return nil
+}
+
+func renderTextDoc(w io.Writer, doc, deprecated string) {
+	if doc != "" {
+		fmt.Fprintf(w, "    %s\n", strings.TrimSpace(doc))
+	}
+	if deprecated != "" {
+		fmt.Fprintf(w, "    // Deprecated: %s\n", deprecated)
+	}
+}
+
+func renderTextValue(w io.Writer, v ValueInfo) {
+	fmt.Fprintln(w, v.Decl)
+	renderTextDoc(w, v.Doc, v.Deprecated)
+	fmt.Fprintln(w)
+}
+
+func renderTextFunc(w io.Writer, fn FuncInfo) {
+	fmt.Fprintln(w, fn.Signature)
+	renderTextDoc(w, fn.Doc, fn.Deprecated)
+	fmt.Fprintln(w)
+}
+
+func renderTextType(w io.Writer, t TypeInfo) {
+	fmt.Fprintln(w, t.Decl)
+	if len(t.Implements) > 0 {
+		fmt.Fprintf(w, "// implements: %s\n", strings.Join(t.Implements, ", "))
+	}
+	renderTextDoc(w, t.Doc, t.Deprecated)
+	fmt.Fprintln(w)
+
+	for _, c := range t.Consts {
+		renderTextValue(w, c)
+	}
+	for _, v := range t.Vars {
+		renderTextValue(w, v)
+	}
+	for _, m := range t.Methods {
+		renderTextFunc(w, m)
+	}
+}
+
+// renderJSON writes summaries as a single JSON array of Summary objects.
+func renderJSON(w io.Writer, summaries []Summary) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(summaries)
+}
+
+// renderMarkdown writes summaries as a Markdown document: one heading per package, one
+// sub-heading per type, and fenced code blocks for signatures and declarations.
+func renderMarkdown(w io.Writer, summaries []Summary) error {
+	for _, s := range summaries {
+		fmt.Fprintf(w, "# %s\n\n", s.Package)
+		if s.Doc != "" {
+			fmt.Fprintf(w, "%s\n\n", strings.TrimSpace(s.Doc))
+		}
+		if s.Deprecated != "" {
+			fmt.Fprintf(w, "> Deprecated: %s\n\n", s.Deprecated)
+		}
+
+		if len(s.Consts) > 0 || len(s.Vars) > 0 {
+			fmt.Fprintf(w, "## Constants and variables\n\n")
+			for _, c := range s.Consts {
+				renderMarkdownValue(w, c)
+			}
+			for _, v := range s.Vars {
+				renderMarkdownValue(w, v)
+			}
+		}
+
+		if len(s.Funcs) > 0 {
+			fmt.Fprintf(w, "## Functions\n\n")
+			for _, fn := range s.Funcs {
+				renderMarkdownFunc(w, fn)
+			}
+		}
+
+		for _, t := range s.Types {
+			fmt.Fprintf(w, "## type %s\n\n", t.Name)
+			fmt.Fprintf(w, "```go\n%s\n```\n\n", t.Decl)
+			if len(t.Implements) > 0 {
+				fmt.Fprintf(w, "Implements: %s\n\n", strings.Join(t.Implements, ", "))
+			}
+			if t.Doc != "" {
+				fmt.Fprintf(w, "%s\n\n", strings.TrimSpace(t.Doc))
+			}
+			if t.Deprecated != "" {
+				fmt.Fprintf(w, "> Deprecated: %s\n\n", t.Deprecated)
+			}
+
+			for _, c := range t.Consts {
+				renderMarkdownValue(w, c)
+			}
+			for _, v := range t.Vars {
+				renderMarkdownValue(w, v)
+			}
+			for _, m := range t.Methods {
+				renderMarkdownFunc(w, m)
+			}
+		}
+	}
+	return nil
+}
+
+func renderMarkdownValue(w io.Writer, v ValueInfo) {
+	fmt.Fprintf(w, "```go\n%s\n```\n\n", v.Decl)
+	if v.Doc != "" {
+		fmt.Fprintf(w, "%s\n\n", strings.TrimSpace(v.Doc))
+	}
+	if v.Deprecated != "" {
+		fmt.Fprintf(w, "> Deprecated: %s\n\n", v.Deprecated)
+	}
+}
+
+func renderMarkdownFunc(w io.Writer, fn FuncInfo) {
+	fmt.Fprintf(w, "### %s\n\n", fn.Name)
+	fmt.Fprintf(w, "```go\n%s\n```\n\n", fn.Signature)
+	if fn.Doc != "" {
+		fmt.Fprintf(w, "%s\n\n", strings.TrimSpace(fn.Doc))
+	}
+	if fn.Deprecated != "" {
+		fmt.Fprintf(w, "> Deprecated: %s\n\n", fn.Deprecated)
+	}
+}
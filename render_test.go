@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestRendererForUnknownFormat checks that an unsupported -format value is rejected.
+func TestRendererForUnknownFormat(t *testing.T) {
+	if _, err := rendererFor("yaml"); err == nil {
+		t.Fatal("expected an error for an unknown format, got nil")
+	}
+}
+
+// TestRenderJSONSchema locks down the shape of the JSON output so downstream tools and LLMs
+// can rely on field names and nesting staying stable.
+func TestRenderJSONSchema(t *testing.T) {
+	summaries, err := summarizeTestdata(t)
+	if err != nil {
+		t.Fatalf("summarizePackage failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderJSON(&buf, summaries); err != nil {
+		t.Fatalf("renderJSON failed: %v", err)
+	}
+
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("expected 1 package summary, got %d", len(decoded))
+	}
+
+	pkg := decoded[0]
+	for _, field := range []string{"package", "dir", "consts", "vars", "funcs", "types"} {
+		if _, ok := pkg[field]; !ok {
+			t.Errorf("expected top-level field %q in JSON output", field)
+		}
+	}
+
+	types, ok := pkg["types"].([]interface{})
+	if !ok || len(types) == 0 {
+		t.Fatalf("expected at least one entry in types, got %v", pkg["types"])
+	}
+
+	var structWithInterface map[string]interface{}
+	for _, raw := range types {
+		typ := raw.(map[string]interface{})
+		if typ["name"] == "StructWithInterface" {
+			structWithInterface = typ
+		}
+	}
+	if structWithInterface == nil {
+		t.Fatal("expected StructWithInterface in types")
+	}
+
+	methods, ok := structWithInterface["methods"].([]interface{})
+	if !ok || len(methods) != 2 {
+		t.Fatalf("expected StructWithInterface to nest 2 methods, got %v", structWithInterface["methods"])
+	}
+	for _, field := range []string{"name", "signature", "receiver", "pos"} {
+		if _, ok := methods[0].(map[string]interface{})[field]; !ok {
+			t.Errorf("expected method field %q in JSON output", field)
+		}
+	}
+}
+
+// TestRenderMarkdown checks that Markdown output groups content under the expected headings.
+func TestRenderMarkdown(t *testing.T) {
+	summaries, err := summarizeTestdata(t)
+	if err != nil {
+		t.Fatalf("summarizePackage failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderMarkdown(&buf, summaries); err != nil {
+		t.Fatalf("renderMarkdown failed: %v", err)
+	}
+	output := buf.String()
+
+	for _, expected := range []string{
+		"# testdata",
+		"## type StructWithInterface",
+		"### ProcessData",
+		"```go",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected markdown output to contain %q, but it didn't", expected)
+		}
+	}
+}
+
+// summarizeTestdata is a shared helper that summarizes ./testdata for the renderer tests above.
+func summarizeTestdata(t *testing.T) ([]Summary, error) {
+	t.Helper()
+	s, err := summarizePackage("testdata", nil)
+	if err != nil {
+		return nil, err
+	}
+	return []Summary{*s}, nil
+}
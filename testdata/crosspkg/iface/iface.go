@@ -0,0 +1,8 @@
+// Package iface declares an exported interface for TestModuleInterfaces to check a type in a
+// sibling package against.
+package iface
+
+// Greeter is implemented by anything that can produce a greeting.
+type Greeter interface {
+	Greet() string
+}
@@ -0,0 +1,12 @@
+// Package impl declares a type that satisfies iface.Greeter without importing it, so
+// TestModuleInterfaces can check that cross-package "implements" detection doesn't depend on
+// an explicit import between the two packages.
+package impl
+
+// English greets in English.
+type English struct{}
+
+// Greet returns the greeting.
+func (English) Greet() string {
+	return "hello"
+}
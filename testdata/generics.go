@@ -0,0 +1,40 @@
+package testdata
+
+// Number is a constraint satisfied by any integer or floating-point type.
+type Number interface {
+	~int | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+// Set is a generic set of comparable values.
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+// Add adds v to the set.
+func (s *Set[T]) Add(v T) {
+	s.items[v] = struct{}{}
+}
+
+// Contains reports whether v is in the set.
+func (s *Set[T]) Contains(v T) bool {
+	_, ok := s.items[v]
+	return ok
+}
+
+// Map applies f to every element of in and returns the results.
+func Map[T any, U comparable](in []T, f func(T) U) []U {
+	out := make([]U, len(in))
+	for i, v := range in {
+		out[i] = f(v)
+	}
+	return out
+}
+
+// Sum adds up a slice of any Number type.
+func Sum[T Number](values []T) T {
+	var total T
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
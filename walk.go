@@ -0,0 +1,102 @@
+package main
+
+import (
+	"go/build"
+	"io"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"strings"
+)
+
+// Options controls which Go files findGoFiles considers part of a tree.
+type Options struct {
+	// IgnoreTests skips files ending in _test.go.
+	IgnoreTests bool
+	// IncludeVendor includes vendor/ directories, which are skipped by default.
+	IncludeVendor bool
+	// IncludeTestdata includes testdata/ directories, which are skipped by default.
+	IncludeTestdata bool
+}
+
+// findGoFiles returns the slice of Go files under root in fsys that match the current build
+// context (GOOS/GOARCH, //go:build constraints, and the _windows.go-style filename
+// conventions), honoring opts. vendor/ and testdata/ directories are skipped unless opted
+// into via Options. fsys lets callers inject an in-memory filesystem for tests.
+func findGoFiles(fsys fs.FS, root string, opts Options) ([]string, error) {
+	ctx := buildContext(fsys)
+
+	var files []string
+	err := fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case "vendor":
+				if !opts.IncludeVendor {
+					return fs.SkipDir
+				}
+			case "testdata":
+				if !opts.IncludeTestdata {
+					return fs.SkipDir
+				}
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		if opts.IgnoreTests && strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		match, err := ctx.MatchFile(pathpkg.Dir(path), pathpkg.Base(path))
+		if err != nil {
+			return err
+		}
+		if !match {
+			return nil
+		}
+
+		files = append(files, path)
+		return nil
+	})
+	return files, err
+}
+
+// buildContext returns a *build.Context wired to read through fsys, so MatchFile's build-tag
+// and GOOS/GOARCH filename checks work against an injected filesystem rather than the OS.
+func buildContext(fsys fs.FS) *build.Context {
+	ctx := build.Default
+	ctx.OpenFile = func(path string) (io.ReadCloser, error) {
+		return fsys.Open(fsPath(path))
+	}
+	ctx.IsDir = func(path string) bool {
+		info, err := fs.Stat(fsys, fsPath(path))
+		return err == nil && info.IsDir()
+	}
+	ctx.ReadDir = func(path string) ([]os.FileInfo, error) {
+		entries, err := fs.ReadDir(fsys, fsPath(path))
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]os.FileInfo, 0, len(entries))
+		for _, e := range entries {
+			info, err := e.Info()
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, info)
+		}
+		return infos, nil
+	}
+	return &ctx
+}
+
+// fsPath normalizes a build.Context path (which may be "." for the tree root) to the form
+// fs.FS expects.
+func fsPath(path string) string {
+	return pathpkg.Clean(path)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"sort"
+	"testing"
+	"testing/fstest"
+)
+
+// TestFindGoFilesSkipsVendorAndTestdata checks that vendor/ and testdata/ are skipped by
+// default but included when the corresponding Options field is set.
+func TestFindGoFilesSkipsVendorAndTestdata(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go":            {Data: []byte("package main\n")},
+		"vendor/dep/dep.go":  {Data: []byte("package dep\n")},
+		"testdata/sample.go": {Data: []byte("package testdata\n")},
+		"subdir/helper.go":   {Data: []byte("package subdir\n")},
+	}
+
+	files, err := findGoFiles(fsys, ".", Options{})
+	if err != nil {
+		t.Fatalf("findGoFiles failed: %v", err)
+	}
+	assertFileSet(t, files, []string{"main.go", "subdir/helper.go"})
+
+	files, err = findGoFiles(fsys, ".", Options{IncludeVendor: true})
+	if err != nil {
+		t.Fatalf("findGoFiles with IncludeVendor failed: %v", err)
+	}
+	assertFileSet(t, files, []string{"main.go", "subdir/helper.go", "vendor/dep/dep.go"})
+
+	files, err = findGoFiles(fsys, ".", Options{IncludeTestdata: true})
+	if err != nil {
+		t.Fatalf("findGoFiles with IncludeTestdata failed: %v", err)
+	}
+	assertFileSet(t, files, []string{"main.go", "subdir/helper.go", "testdata/sample.go"})
+}
+
+// TestFindGoFilesHonorsBuildConstraints checks that files excluded by a //go:build line or by
+// a GOOS/GOARCH filename suffix are not returned for the current build context.
+func TestFindGoFilesHonorsBuildConstraints(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go": {Data: []byte("package main\n")},
+		"only_impossible_arch.go": {
+			Data: []byte("//go:build impossiblearch\n\npackage main\n"),
+		},
+		"helper_windows.go": {Data: []byte("package main\n")},
+	}
+
+	files, err := findGoFiles(fsys, ".", Options{})
+	if err != nil {
+		t.Fatalf("findGoFiles failed: %v", err)
+	}
+	assertFileSet(t, files, []string{"main.go"})
+}
+
+func assertFileSet(t *testing.T, got []string, want []string) {
+	t.Helper()
+	gotSorted := append([]string(nil), got...)
+	sort.Strings(gotSorted)
+	wantSorted := append([]string(nil), want...)
+	sort.Strings(wantSorted)
+
+	if len(gotSorted) != len(wantSorted) {
+		t.Fatalf("expected files %v, got %v", wantSorted, gotSorted)
+	}
+	for i := range gotSorted {
+		if gotSorted[i] != wantSorted[i] {
+			t.Fatalf("expected files %v, got %v", wantSorted, gotSorted)
+		}
+	}
+}